@@ -261,8 +261,7 @@ func (c *cmdGroupEdit) run(cmd *cobra.Command, args []string) error {
 			return err
 		}
 
-		newdata := api.AuthGroupPut{}
-		err = yaml.Unmarshal(contents, &newdata)
+		newdata, err := parseGroupEditContents(contents)
 		if err != nil {
 			return err
 		}
@@ -289,8 +288,7 @@ func (c *cmdGroupEdit) run(cmd *cobra.Command, args []string) error {
 
 	for {
 		// Parse the text received from the editor
-		newdata := api.AuthGroupPut{}
-		err = yaml.Unmarshal(content, &newdata)
+		newdata, err := parseGroupEditContents(content)
 		if err == nil {
 			err = resource.server.UpdateAuthGroup(resource.name, newdata, etag)
 		}
@@ -319,6 +317,18 @@ func (c *cmdGroupEdit) run(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// parseGroupEditContents parses the YAML given to `lxc auth group edit`, either from stdin or from the editor. The
+// contents may be the full output of `lxc auth group show`, in which case the read-only fields are ignored.
+func parseGroupEditContents(contents []byte) (api.AuthGroupPut, error) {
+	newdata := api.AuthGroupPut{}
+	err := yaml.Unmarshal(contents, &newdata)
+	if err != nil {
+		return api.AuthGroupPut{}, err
+	}
+
+	return newdata, nil
+}
+
 type cmdGroupList struct {
 	global     *cmdGlobal
 	flagFormat string
@@ -689,6 +699,12 @@ func parsePermissionArgs(args []string) (*api.Permission, error) {
 		return nil, fmt.Errorf("Entities of type %q require a supplementary project argument `project=<project_name>`", entityType)
 	}
 
+	// Operations and warnings may optionally belong to a project. Any other entity that is not project specific
+	// ignores the project argument, as the server would not be able to find the entity it refers to.
+	if !requiresProject && entityType != entity.TypeOperation && entityType != entity.TypeWarning {
+		projectName = ""
+	}
+
 	if entityType == entity.TypeStorageVolume {
 		storageVolumeType, ok := kv["type"]
 		if !ok {
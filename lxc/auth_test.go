@@ -0,0 +1,124 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+
+	"github.com/canonical/lxd/shared/api"
+)
+
+// The output of `lxc auth group show` can be passed directly to `lxc auth group edit`. The read-only fields of the
+// group are ignored.
+func TestGroupEditAcceptsGroupShowOutput(t *testing.T) {
+	group := api.AuthGroup{
+		Name:        "operators",
+		Description: "Project operators",
+		Permissions: []api.Permission{
+			{
+				EntityType:      "project",
+				EntityReference: "/1.0/projects/default",
+				Entitlement:     "operator",
+			},
+		},
+		Identities: map[string][]string{
+			api.AuthenticationMethodOIDC: {"jane.doe@example.com"},
+		},
+		IdentityProviderGroups: []string{"sales"},
+	}
+
+	contents, err := yaml.Marshal(&group)
+	require.NoError(t, err)
+
+	// Input from stdin.
+	newdata, err := parseGroupEditContents(contents)
+	require.NoError(t, err)
+	assert.Equal(t, group.Writable(), newdata)
+
+	// Input from the editor, including the help template.
+	c := cmdGroupEdit{}
+	newdata, err = parseGroupEditContents([]byte(c.helpTemplate() + "\n\n" + string(contents)))
+	require.NoError(t, err)
+	assert.Equal(t, group.Writable(), newdata)
+}
+
+func TestParsePermissionArgs(t *testing.T) {
+	tests := []struct {
+		name              string
+		args              []string
+		expectedReference string
+		expectErr         bool
+	}{
+		{
+			name:              "Server",
+			args:              []string{"operators", "server", "admin"},
+			expectedReference: "/1.0",
+		},
+		{
+			name:              "Project specific entity",
+			args:              []string{"operators", "instance", "c1", "operator", "project=foo"},
+			expectedReference: "/1.0/instances/c1?project=foo",
+		},
+		{
+			name:      "Project specific entity without project",
+			args:      []string{"operators", "instance", "c1", "operator"},
+			expectErr: true,
+		},
+		{
+			name:              "Storage volume",
+			args:              []string{"operators", "storage_volume", "vol1", "can_edit", "project=foo", "pool=default", "type=custom"},
+			expectedReference: "/1.0/storage-pools/default/volumes/custom/vol1?project=foo",
+		},
+		{
+			name:              "Storage bucket",
+			args:              []string{"operators", "storage_bucket", "bucket1", "can_edit", "project=foo", "pool=default"},
+			expectedReference: "/1.0/storage-pools/default/buckets/bucket1?project=foo",
+		},
+		{
+			name:              "Project",
+			args:              []string{"operators", "project", "foo", "operator", "project=bar"},
+			expectedReference: "/1.0/projects/foo",
+		},
+		{
+			name:              "Storage pool",
+			args:              []string{"operators", "storage_pool", "default", "can_edit", "project=foo"},
+			expectedReference: "/1.0/storage-pools/default",
+		},
+		{
+			name:              "Certificate",
+			args:              []string{"operators", "certificate", "abcdef", "can_view", "project=foo"},
+			expectedReference: "/1.0/certificates/abcdef",
+		},
+		{
+			name:              "Identity",
+			args:              []string{"operators", "identity", "oidc/jane.doe@example.com", "can_view", "project=foo"},
+			expectedReference: "/1.0/auth/identities/oidc/jane.doe@example.com",
+		},
+		{
+			name:              "Warning",
+			args:              []string{"operators", "warning", "b7a7a3a4-3b5e-4a3c-8a8d-5e2f0e3b9c1d", "can_view", "project=foo"},
+			expectedReference: "/1.0/warnings/b7a7a3a4-3b5e-4a3c-8a8d-5e2f0e3b9c1d?project=foo",
+		},
+		{
+			name:              "Operation",
+			args:              []string{"operators", "operation", "b7a7a3a4-3b5e-4a3c-8a8d-5e2f0e3b9c1d", "can_view", "project=foo"},
+			expectedReference: "/1.0/operations/b7a7a3a4-3b5e-4a3c-8a8d-5e2f0e3b9c1d?project=foo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			permission, err := parsePermissionArgs(tt.args)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.args[1], permission.EntityType)
+			assert.Equal(t, tt.expectedReference, permission.EntityReference)
+		})
+	}
+}
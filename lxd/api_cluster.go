@@ -1051,7 +1051,7 @@ func clusterInitMember(d lxd.InstanceServer, client lxd.InstanceServer, memberCo
 		}
 	}
 
-	revert, err := initDataNodeApply(d, data)
+	revert, err := initDataNodeApply(d, data, initDataNodeApplyArgs{})
 	if err != nil {
 		return nil, fmt.Errorf("Failed to initialize storage pools and networks: %w", err)
 	}
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/oklog/ulid/v2"
@@ -415,14 +416,29 @@ func (e *embeddedOpenFGA) GetPermissionChecker(ctx context.Context, r *http.Requ
 		return nil, fmt.Errorf("Failed to list OpenFGA objects of type %q with entitlement %q for user %q: %w", entityType.String(), entitlement, username, err)
 	}
 
-	objects := resp.GetObjects()
+	return newListObjectsPermissionChecker(entityType, resp.GetObjects()), nil
+}
+
+// newListObjectsPermissionChecker returns a PermissionChecker for the objects in a ListObjects response. The objects are
+// indexed once up front so that each call to the returned PermissionChecker is a constant time lookup. This matters
+// when filtering long lists of entities, since the checker is called once per entity.
+func newListObjectsPermissionChecker(entityType entity.Type, objects []string) auth.PermissionChecker {
+	entityURLs := make(map[string]struct{}, len(objects))
+	for _, object := range objects {
+		objectEntityType, entityURL, ok := strings.Cut(object, ":")
+		if !ok || objectEntityType != entityType.String() {
+			continue
+		}
 
-	// Return a permission checker that constructs an OpenFGA object from the given URL and returns true if the object is
-	// found in the list of objects in the response.
+		entityURLs[entityURL] = struct{}{}
+	}
+
+	// Return a permission checker that returns true if the URL of the given entity is found in the list of objects in
+	// the response.
 	return func(entityURL *api.URL) bool {
-		object := fmt.Sprintf("%s:%s", entityType, entityURL.String())
-		return shared.ValueInSlice(object, objects)
-	}, nil
+		_, ok := entityURLs[entityURL.String()]
+		return ok
+	}
 }
 
 // openfgaLogger implements OpenFGAs logger.Logger interface but delegates to our logger.
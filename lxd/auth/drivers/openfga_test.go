@@ -0,0 +1,53 @@
+//go:build linux && cgo && !agent
+
+package drivers
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/canonical/lxd/shared/api"
+	"github.com/canonical/lxd/shared/entity"
+)
+
+func TestNewListObjectsPermissionChecker(t *testing.T) {
+	objects := []string{
+		fmt.Sprintf("%s:%s", entity.TypeInstance, entity.InstanceURL("default", "c1")),
+		fmt.Sprintf("%s:%s", entity.TypeInstance, entity.InstanceURL("foo", "c1")),
+		// Objects of other types are never matched.
+		fmt.Sprintf("%s:%s", entity.TypeProject, entity.ProjectURL("default")),
+	}
+
+	checker := newListObjectsPermissionChecker(entity.TypeInstance, objects)
+
+	assert.True(t, checker(entity.InstanceURL("default", "c1")))
+	assert.True(t, checker(entity.InstanceURL("foo", "c1")))
+	assert.False(t, checker(entity.InstanceURL("default", "c2")))
+	assert.False(t, checker(entity.InstanceURL("bar", "c1")))
+	assert.False(t, checker(entity.ProjectURL("default")))
+}
+
+func BenchmarkListObjectsPermissionChecker(b *testing.B) {
+	const numObjects = 10000
+
+	objects := make([]string, 0, numObjects)
+	entityURLs := make([]*api.URL, 0, numObjects)
+	for i := 0; i < numObjects; i++ {
+		u := entity.InstanceURL("default", fmt.Sprintf("c%d", i))
+		objects = append(objects, fmt.Sprintf("%s:%s", entity.TypeInstance, u))
+		entityURLs = append(entityURLs, u)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// Build the checker and filter every entity, as a list handler would.
+		checker := newListObjectsPermissionChecker(entity.TypeInstance, objects)
+		for _, u := range entityURLs {
+			if !checker(u) {
+				b.Fatalf("Expected %q to be allowed", u)
+			}
+		}
+	}
+}
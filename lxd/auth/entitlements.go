@@ -4,6 +4,7 @@ package auth
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/canonical/lxd/shared"
 	"github.com/canonical/lxd/shared/entity"
@@ -17,6 +18,13 @@ func ValidateEntitlement(entityType entity.Type, entitlement Entitlement) error
 	}
 
 	if !shared.ValueInSlice(entitlement, entitlements) {
+		// Entitlements are case-sensitive, suggest the correct casing if that is the only difference.
+		for _, validEntitlement := range entitlements {
+			if strings.EqualFold(string(entitlement), string(validEntitlement)) {
+				return fmt.Errorf("Entitlement %q not valid for entity type %q (entitlements are case-sensitive, did you mean %q?)", entitlement, entityType, validEntitlement)
+			}
+		}
+
 		return fmt.Errorf("Entitlement %q not valid for entity type %q", entitlement, entityType)
 	}
 
@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/canonical/lxd/shared/entity"
+)
+
+func TestValidateEntitlement(t *testing.T) {
+	tests := []struct {
+		name        string
+		entityType  entity.Type
+		entitlement Entitlement
+		expectedErr string
+	}{
+		{
+			name:        "Valid entitlement",
+			entityType:  entity.TypeProject,
+			entitlement: EntitlementCanView,
+		},
+		{
+			name:        "Mixed case entitlement",
+			entityType:  entity.TypeProject,
+			entitlement: "Can_View",
+			expectedErr: `Entitlement "Can_View" not valid for entity type "project" (entitlements are case-sensitive, did you mean "can_view"?)`,
+		},
+		{
+			name:        "Upper case entitlement",
+			entityType:  entity.TypeServer,
+			entitlement: "ADMIN",
+			expectedErr: `Entitlement "ADMIN" not valid for entity type "server" (entitlements are case-sensitive, did you mean "admin"?)`,
+		},
+		{
+			name:        "Unknown entitlement",
+			entityType:  entity.TypeProject,
+			entitlement: "can_fly",
+			expectedErr: `Entitlement "can_fly" not valid for entity type "project"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateEntitlement(tt.entityType, tt.entitlement)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
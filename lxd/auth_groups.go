@@ -621,14 +621,16 @@ func patchAuthGroup(d *Daemon, r *http.Request) response.Response {
 			}
 		}
 
-		newPermissions := make([]api.Permission, 0, len(groupPut.Permissions))
+		// Add the given permissions to the existing ones. Only the permissions that the group doesn't already have
+		// are written.
+		permissions := apiGroup.Permissions
 		for _, permission := range groupPut.Permissions {
-			if !shared.ValueInSlice(permission, apiGroup.Permissions) {
-				newPermissions = append(newPermissions, permission)
+			if !shared.ValueInSlice(permission, permissions) {
+				permissions = append(permissions, permission)
 			}
 		}
 
-		err = upsertPermissions(ctx, tx.Tx(), group.ID, newPermissions)
+		err = upsertPermissions(ctx, tx.Tx(), group.ID, permissions)
 		if err != nil {
 			return err
 		}
@@ -826,8 +828,9 @@ func validatePermissions(permissions []api.Permission) error {
 
 // upsertPermissions converts the given slice of api.Permission into a slice of cluster.Permission by resolving
 // the URLs of each permission to an entity ID. Then sets those permissions against the group with the given ID.
+// If any of the entities do not exist, a single not found error listing all of them is returned.
 func upsertPermissions(ctx context.Context, tx *sql.Tx, groupID int, permissions []api.Permission) error {
-	entityReferences := make(map[*api.URL]*dbCluster.EntityRef, len(permissions))
+	entityURLs := make([]*api.URL, 0, len(permissions))
 	permissionToURL := make(map[api.Permission]*api.URL, len(permissions))
 	for _, permission := range permissions {
 		u, err := url.Parse(permission.EntityReference)
@@ -836,20 +839,32 @@ func upsertPermissions(ctx context.Context, tx *sql.Tx, groupID int, permissions
 		}
 
 		apiURL := &api.URL{URL: *u}
-		entityReferences[apiURL] = &dbCluster.EntityRef{}
+		entityURLs = append(entityURLs, apiURL)
 		permissionToURL[permission] = apiURL
 	}
 
-	err := dbCluster.PopulateEntityReferencesFromURLs(ctx, tx, entityReferences)
+	entityReferences, err := dbCluster.GetEntityReferencesFromURLs(ctx, tx, entityURLs...)
 	if err != nil {
 		return err
 	}
 
+	var missingEntities []string
+	for _, permission := range permissions {
+		result, ok := entityReferences[permissionToURL[permission]]
+		if ok && !result.Exists && !shared.ValueInSlice(permission.EntityReference, missingEntities) {
+			missingEntities = append(missingEntities, permission.EntityReference)
+		}
+	}
+
+	if len(missingEntities) > 0 {
+		return api.StatusErrorf(http.StatusNotFound, "Entities not found: %s", strings.Join(missingEntities, ", "))
+	}
+
 	authGroupPermissions := make([]dbCluster.Permission, 0, len(permissions))
 	for permission, apiURL := range permissionToURL {
 		entitlement := auth.Entitlement(permission.Entitlement)
 		entityType := dbCluster.EntityType(permission.EntityType)
-		entityRef, ok := entityReferences[apiURL]
+		result, ok := entityReferences[apiURL]
 		if !ok {
 			return api.StatusErrorf(http.StatusBadRequest, "Missing entity ID for permission with URL %q", permission.EntityReference)
 		}
@@ -858,7 +873,7 @@ func upsertPermissions(ctx context.Context, tx *sql.Tx, groupID int, permissions
 			GroupID:     groupID,
 			Entitlement: entitlement,
 			EntityType:  entityType,
-			EntityID:    entityRef.EntityID,
+			EntityID:    result.EntityRef.EntityID,
 		})
 	}
 
@@ -274,24 +274,60 @@ func GetPermissions(ctx context.Context, tx *sql.Tx) ([]Permission, error) {
 	return result, nil
 }
 
-// SetAuthGroupPermissions deletes all auth_group -> permission mappings from the `auth_group_permissions` table
-// where the group ID is equal to the given value. Then it inserts a new row for each given permission ID.
+// SetAuthGroupPermissions sets the permissions of the group with the given ID to the given permissions. Only the
+// difference between the existing and the given permissions is written: rows for permissions that are no longer
+// wanted are deleted and rows for new permissions are inserted. Existing rows for permissions that are kept are not
+// modified. Permissions that are given more than once (e.g. because two entity references resolve to the same entity)
+// are only inserted once.
 func SetAuthGroupPermissions(ctx context.Context, tx *sql.Tx, groupID int, authGroupPermissions []Permission) error {
-	_, err := tx.ExecContext(ctx, `DELETE FROM auth_groups_permissions WHERE auth_group_id = ?`, groupID)
+	// permissionKey identifies a permission independently of its row ID.
+	type permissionKey struct {
+		entitlement auth.Entitlement
+		entityType  EntityType
+		entityID    int
+	}
+
+	existingPermissions, err := GetPermissionsByAuthGroupID(ctx, tx, groupID)
 	if err != nil {
-		return fmt.Errorf("Failed to delete existing permissions for group with ID `%d`: %w", groupID, err)
+		return err
 	}
 
-	if len(authGroupPermissions) == 0 {
-		return nil
+	existing := make(map[permissionKey]int, len(existingPermissions))
+	for _, permission := range existingPermissions {
+		existing[permissionKey{entitlement: permission.Entitlement, entityType: permission.EntityType, entityID: permission.EntityID}] = permission.ID
 	}
 
+	wanted := make(map[permissionKey]struct{}, len(authGroupPermissions))
 	for _, permission := range authGroupPermissions {
-		_, err := tx.ExecContext(ctx, `INSERT INTO auth_groups_permissions (auth_group_id, entity_type, entity_id, entitlement) VALUES (?, ?, ?, ?);`, permission.GroupID, permission.EntityType, permission.EntityID, permission.Entitlement)
+		key := permissionKey{entitlement: permission.Entitlement, entityType: permission.EntityType, entityID: permission.EntityID}
+		_, ok := wanted[key]
+		if ok {
+			continue
+		}
+
+		wanted[key] = struct{}{}
+		_, ok = existing[key]
+		if ok {
+			continue
+		}
+
+		_, err := tx.ExecContext(ctx, `INSERT INTO auth_groups_permissions (auth_group_id, entity_type, entity_id, entitlement) VALUES (?, ?, ?, ?);`, groupID, permission.EntityType, permission.EntityID, permission.Entitlement)
 		if err != nil {
 			return fmt.Errorf("Failed to write group permissions: %w", err)
 		}
 	}
 
+	for key, permissionID := range existing {
+		_, ok := wanted[key]
+		if ok {
+			continue
+		}
+
+		_, err := tx.ExecContext(ctx, `DELETE FROM auth_groups_permissions WHERE id = ?`, permissionID)
+		if err != nil {
+			return fmt.Errorf("Failed to delete permission with ID `%d` from group with ID `%d`: %w", permissionID, groupID, err)
+		}
+	}
+
 	return nil
 }
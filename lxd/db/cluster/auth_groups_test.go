@@ -0,0 +1,92 @@
+package cluster
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/canonical/lxd/lxd/auth"
+	"github.com/canonical/lxd/shared/entity"
+)
+
+func TestSetAuthGroupPermissions(t *testing.T) {
+	schema := Schema()
+	db, err := schema.ExerciseUpdate(len(updates), nil)
+	require.NoError(t, err)
+
+	_, err = db.Exec(`INSERT INTO auth_groups (id, name, description) VALUES (1, 'operators', '')`)
+	require.NoError(t, err)
+
+	tx, err := db.Begin()
+	require.NoError(t, err)
+	defer func() { _ = tx.Rollback() }()
+
+	ctx := context.Background()
+
+	// totalChanges returns the number of rows modified by the transaction so far.
+	totalChanges := func(tx *sql.Tx) int {
+		var changes int
+		err := tx.QueryRowContext(ctx, `SELECT total_changes()`).Scan(&changes)
+		require.NoError(t, err)
+		return changes
+	}
+
+	permission := func(entitlement auth.Entitlement) Permission {
+		return Permission{GroupID: 1, Entitlement: entitlement, EntityType: EntityType(entity.TypeProject), EntityID: 1}
+	}
+
+	permissionIDs := func() map[auth.Entitlement]int {
+		permissions, err := GetPermissionsByAuthGroupID(ctx, tx, 1)
+		require.NoError(t, err)
+
+		ids := make(map[auth.Entitlement]int, len(permissions))
+		for _, p := range permissions {
+			ids[p.Entitlement] = p.ID
+		}
+
+		return ids
+	}
+
+	// Initial permissions, including a duplicate.
+	err = SetAuthGroupPermissions(ctx, tx, 1, []Permission{permission(auth.EntitlementViewer), permission(auth.EntitlementOperator), permission(auth.EntitlementViewer)})
+	require.NoError(t, err)
+	initialIDs := permissionIDs()
+	assert.Len(t, initialIDs, 2)
+
+	// A no-op update doesn't write anything.
+	changes := totalChanges(tx)
+	err = SetAuthGroupPermissions(ctx, tx, 1, []Permission{permission(auth.EntitlementOperator), permission(auth.EntitlementViewer)})
+	require.NoError(t, err)
+	assert.Equal(t, changes, totalChanges(tx))
+	assert.Equal(t, initialIDs, permissionIDs())
+
+	// Adding a permission only inserts one row and leaves the existing rows in place.
+	changes = totalChanges(tx)
+	err = SetAuthGroupPermissions(ctx, tx, 1, []Permission{permission(auth.EntitlementOperator), permission(auth.EntitlementViewer), permission(auth.EntitlementCanEdit)})
+	require.NoError(t, err)
+	assert.Equal(t, changes+1, totalChanges(tx))
+
+	ids := permissionIDs()
+	assert.Len(t, ids, 3)
+	assert.Equal(t, initialIDs[auth.EntitlementViewer], ids[auth.EntitlementViewer])
+	assert.Equal(t, initialIDs[auth.EntitlementOperator], ids[auth.EntitlementOperator])
+
+	// Removing a permission only deletes one row.
+	changes = totalChanges(tx)
+	err = SetAuthGroupPermissions(ctx, tx, 1, []Permission{permission(auth.EntitlementViewer), permission(auth.EntitlementCanEdit)})
+	require.NoError(t, err)
+	assert.Equal(t, changes+1, totalChanges(tx))
+
+	ids = permissionIDs()
+	assert.Len(t, ids, 2)
+	assert.Equal(t, initialIDs[auth.EntitlementViewer], ids[auth.EntitlementViewer])
+	assert.NotContains(t, ids, auth.EntitlementOperator)
+
+	// Removing all permissions.
+	err = SetAuthGroupPermissions(ctx, tx, 1, nil)
+	require.NoError(t, err)
+	assert.Empty(t, permissionIDs())
+}
@@ -589,6 +589,12 @@ type EntityRef struct {
 	PathArgs    []string
 }
 
+// exists returns true if the EntityRef has been resolved to an entity. The server entity always exists and has an
+// ID of zero.
+func (e *EntityRef) exists() bool {
+	return e.EntityID != 0 || e.EntityType == EntityType(entity.TypeServer)
+}
+
 // scan accepts a scanning function (e.g. `(*sql.Row).Scan`) and uses it to parse the row and set its fields.
 func (e *EntityRef) scan(scan func(dest ...any) error) error {
 	var pathArgs string
@@ -1043,6 +1049,59 @@ var entityIDFromURLStatements = map[entity.Type]string{
 // PopulateEntityReferencesFromURLs populates the values in the given map with entity references corresponding to the api.URL keys.
 // It will return an error if any of the given URLs do not correspond to a LXD entity.
 func PopulateEntityReferencesFromURLs(ctx context.Context, tx *sql.Tx, entityURLMap map[*api.URL]*EntityRef) error {
+	err := populateEntityReferencesFromURLs(ctx, tx, entityURLMap)
+	if err != nil {
+		return err
+	}
+
+	// Check that all given URLs have been resolved to an ID.
+	for u, ref := range entityURLMap {
+		if !ref.exists() {
+			return fmt.Errorf("Failed to find entity ID for URL %q", u.String())
+		}
+	}
+
+	return nil
+}
+
+// EntityRefResult is the result of resolving a single URL with GetEntityReferencesFromURLs.
+type EntityRefResult struct {
+	// EntityRef is the entity reference parsed from the URL. The EntityID is only set if the entity exists.
+	EntityRef *EntityRef
+
+	// Exists is true if the URL corresponds to an existing LXD entity.
+	Exists bool
+}
+
+// GetEntityReferencesFromURLs resolves each of the given URLs to an entity reference. Unlike
+// PopulateEntityReferencesFromURLs, it does not return an error if an entity does not exist. Instead, the Exists field
+// of the corresponding EntityRefResult is set to false. This allows callers to report all missing entities at once.
+// An error is still returned if any of the URLs cannot be parsed.
+func GetEntityReferencesFromURLs(ctx context.Context, tx *sql.Tx, entityURLs ...*api.URL) (map[*api.URL]EntityRefResult, error) {
+	entityURLMap := make(map[*api.URL]*EntityRef, len(entityURLs))
+	for _, entityURL := range entityURLs {
+		entityURLMap[entityURL] = &EntityRef{}
+	}
+
+	err := populateEntityReferencesFromURLs(ctx, tx, entityURLMap)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[*api.URL]EntityRefResult, len(entityURLMap))
+	for u, ref := range entityURLMap {
+		result[u] = EntityRefResult{
+			EntityRef: ref,
+			Exists:    ref.exists(),
+		}
+	}
+
+	return result, nil
+}
+
+// populateEntityReferencesFromURLs populates the values in the given map with entity references corresponding to the
+// api.URL keys. The EntityID of any entity that does not exist is left unset.
+func populateEntityReferencesFromURLs(ctx context.Context, tx *sql.Tx, entityURLMap map[*api.URL]*EntityRef) error {
 	// If the input list is empty, nothing to do.
 	if len(entityURLMap) == 0 {
 		return nil
@@ -1129,13 +1188,6 @@ func PopulateEntityReferencesFromURLs(ctx context.Context, tx *sql.Tx, entityURL
 		return fmt.Errorf("Failed to get entity IDs from URLs: %w", err)
 	}
 
-	// Check that all given URLs have been resolved to an ID.
-	for u, ref := range entityURLMap {
-		if ref.EntityID == 0 && ref.EntityType != EntityType(entity.TypeServer) {
-			return fmt.Errorf("Failed to find entity ID for URL %q", u.String())
-		}
-	}
-
 	return nil
 }
 
@@ -1,11 +1,17 @@
 package cluster
 
 import (
+	"context"
+	"fmt"
+	"net/http"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/canonical/lxd/shared/api"
+	"github.com/canonical/lxd/shared/entity"
 )
 
 func TestEntityStatementValidity(t *testing.T) {
@@ -47,3 +53,103 @@ func TestEntityStatementValidity(t *testing.T) {
 		}
 	}
 }
+
+func TestGetEntityReferencesFromURLs(t *testing.T) {
+	schema := Schema()
+	db, err := schema.ExerciseUpdate(len(updates), nil)
+	require.NoError(t, err)
+
+	_, err = db.Exec(`INSERT INTO auth_groups (name, description) VALUES ('operators', '')`)
+	require.NoError(t, err)
+
+	tx, err := db.Begin()
+	require.NoError(t, err)
+	defer func() { _ = tx.Rollback() }()
+
+	defaultProjectURL := entity.ProjectURL(api.ProjectDefaultName)
+	missingProjectURL := entity.ProjectURL("missing")
+	groupURL := entity.AuthGroupURL("operators")
+	missingGroupURL := entity.AuthGroupURL("missing")
+	serverURL := entity.ServerURL()
+
+	result, err := GetEntityReferencesFromURLs(context.Background(), tx, defaultProjectURL, missingProjectURL, groupURL, missingGroupURL, serverURL)
+	require.NoError(t, err)
+	require.Len(t, result, 5)
+
+	assert.True(t, result[defaultProjectURL].Exists)
+	assert.Equal(t, 1, result[defaultProjectURL].EntityRef.EntityID)
+	assert.Equal(t, EntityType(entity.TypeProject), result[defaultProjectURL].EntityRef.EntityType)
+
+	assert.False(t, result[missingProjectURL].Exists)
+	assert.Equal(t, 0, result[missingProjectURL].EntityRef.EntityID)
+	assert.Equal(t, []string{"missing"}, result[missingProjectURL].EntityRef.PathArgs)
+
+	assert.True(t, result[groupURL].Exists)
+	assert.NotZero(t, result[groupURL].EntityRef.EntityID)
+
+	assert.False(t, result[missingGroupURL].Exists)
+	assert.True(t, result[serverURL].Exists)
+
+	// The strict variant fails if any of the entities are missing.
+	err = PopulateEntityReferencesFromURLs(context.Background(), tx, map[*api.URL]*EntityRef{
+		defaultProjectURL: {},
+		missingGroupURL:   {},
+	})
+	assert.Error(t, err)
+}
+
+// Storage volumes with the same name on different cluster members are distinct entities, so the location in the URL
+// must be taken into account when resolving the entity ID.
+func TestStorageVolumeEntityReferenceLocation(t *testing.T) {
+	schema := Schema()
+	db, err := schema.ExerciseUpdate(len(updates), nil)
+	require.NoError(t, err)
+
+	stmts := []string{
+		`INSERT INTO nodes (id, name, description, address, schema, api_extensions, arch) VALUES (101, 'member-a', '', '10.0.0.1', 1, 1, 1)`,
+		`INSERT INTO nodes (id, name, description, address, schema, api_extensions, arch) VALUES (102, 'member-b', '', '10.0.0.2', 1, 1, 1)`,
+		`INSERT INTO storage_pools (id, name, driver, description) VALUES (1, 'local', 'dir', '')`,
+		fmt.Sprintf(`INSERT INTO storage_volumes (id, name, storage_pool_id, node_id, type, description, project_id) VALUES (11, 'vol1', 1, 101, %d, '', 1)`, StoragePoolVolumeTypeCustom),
+		fmt.Sprintf(`INSERT INTO storage_volumes (id, name, storage_pool_id, node_id, type, description, project_id) VALUES (12, 'vol1', 1, 102, %d, '', 1)`, StoragePoolVolumeTypeCustom),
+	}
+
+	for _, stmt := range stmts {
+		_, err = db.Exec(stmt)
+		require.NoError(t, err)
+	}
+
+	tx, err := db.Begin()
+	require.NoError(t, err)
+	defer func() { _ = tx.Rollback() }()
+
+	volumeA := entity.StorageVolumeURL(api.ProjectDefaultName, "member-a", "local", StoragePoolVolumeTypeNameCustom, "vol1")
+	volumeB := entity.StorageVolumeURL(api.ProjectDefaultName, "member-b", "local", StoragePoolVolumeTypeNameCustom, "vol1")
+	volumeC := entity.StorageVolumeURL(api.ProjectDefaultName, "member-c", "local", StoragePoolVolumeTypeNameCustom, "vol1")
+	volumeNoLocation := entity.StorageVolumeURL(api.ProjectDefaultName, "", "local", StoragePoolVolumeTypeNameCustom, "vol1")
+
+	result, err := GetEntityReferencesFromURLs(context.Background(), tx, volumeA, volumeB, volumeC, volumeNoLocation)
+	require.NoError(t, err)
+
+	assert.True(t, result[volumeA].Exists)
+	assert.Equal(t, 11, result[volumeA].EntityRef.EntityID)
+	assert.True(t, result[volumeB].Exists)
+	assert.Equal(t, 12, result[volumeB].EntityRef.EntityID)
+
+	// A grant for a volume on one member can never refer to the same-named volume on another member.
+	assert.False(t, result[volumeC].Exists)
+	assert.False(t, result[volumeNoLocation].Exists)
+
+	// Authorization checks resolve single entities, which must also take the location into account.
+	entityRef, err := GetEntityReferenceFromURL(context.Background(), tx, volumeA)
+	require.NoError(t, err)
+	assert.Equal(t, 11, entityRef.EntityID)
+
+	entityRef, err = GetEntityReferenceFromURL(context.Background(), tx, volumeB)
+	require.NoError(t, err)
+	assert.Equal(t, 12, entityRef.EntityID)
+
+	for _, u := range []*api.URL{volumeC, volumeNoLocation} {
+		_, err = GetEntityReferenceFromURL(context.Background(), tx, u)
+		assert.Truef(t, api.StatusErrorCheck(err, http.StatusNotFound), "Expected not found error for %q, got: %v", u, err)
+	}
+}
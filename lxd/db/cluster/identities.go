@@ -12,11 +12,13 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/canonical/lxd/lxd/auth"
 	"github.com/canonical/lxd/lxd/certificate"
 	"github.com/canonical/lxd/lxd/db/query"
 	"github.com/canonical/lxd/lxd/identity"
+	"github.com/canonical/lxd/shared"
 	"github.com/canonical/lxd/shared/api"
 	"github.com/canonical/lxd/shared/entity"
 )
@@ -417,7 +419,8 @@ func GetIdentityByNameOrIdentifier(ctx context.Context, tx *sql.Tx, authenticati
 
 // SetIdentityAuthGroups deletes all auth_group -> identity mappings from the `identities_auth_groups` table
 // where the identity ID is equal to the given value. Then it inserts new associations into the table where the
-// group IDs correspond to the given group names.
+// group IDs correspond to the given group names. If any of the groups do not exist, an api.StatusError with
+// http.StatusNotFound is returned.
 func SetIdentityAuthGroups(ctx context.Context, tx *sql.Tx, identityID int, groupNames []string) error {
 	_, err := tx.ExecContext(ctx, `DELETE FROM identities_auth_groups WHERE identity_id = ?`, identityID)
 	if err != nil {
@@ -428,9 +431,17 @@ func SetIdentityAuthGroups(ctx context.Context, tx *sql.Tx, identityID int, grou
 		return nil
 	}
 
-	args := []any{identityID}
+	// Group names may be given more than once, but each group is only associated with the identity once.
+	uniqueGroupNames := make([]string, 0, len(groupNames))
 	for _, groupName := range groupNames {
-		args = append(args, groupName)
+		if !shared.ValueInSlice(groupName, uniqueGroupNames) {
+			uniqueGroupNames = append(uniqueGroupNames, groupName)
+		}
+	}
+
+	groupNameArgs := make([]any, 0, len(uniqueGroupNames))
+	for _, groupName := range uniqueGroupNames {
+		groupNameArgs = append(groupNameArgs, groupName)
 	}
 
 	q := fmt.Sprintf(`
@@ -438,9 +449,9 @@ INSERT INTO identities_auth_groups (identity_id, auth_group_id)
 SELECT ?, auth_groups.id
 FROM auth_groups
 WHERE auth_groups.name IN %s
-`, query.Params(len(groupNames)))
+`, query.Params(len(uniqueGroupNames)))
 
-	res, err := tx.ExecContext(ctx, q, args...)
+	res, err := tx.ExecContext(ctx, q, append([]any{identityID}, groupNameArgs...)...)
 	if err != nil {
 		return fmt.Errorf("Failed to write identity auth group associations: %w", err)
 	}
@@ -450,9 +461,22 @@ WHERE auth_groups.name IN %s
 		return fmt.Errorf("Failed to check validity of identity auth group associations: %w", err)
 	}
 
-	if int(rowsAffected) != len(groupNames) {
-		return fmt.Errorf("Failed to write expected number of rows to identity auth group association table (expected %d, got %d)", len(groupNames), rowsAffected)
+	if int(rowsAffected) == len(uniqueGroupNames) {
+		return nil
 	}
 
-	return nil
+	// Not all of the groups exist, find out which ones are missing so that the caller gets a useful error.
+	existingGroupNames, err := query.SelectStrings(ctx, tx, fmt.Sprintf(`SELECT name FROM auth_groups WHERE name IN %s`, query.Params(len(uniqueGroupNames))), groupNameArgs...)
+	if err != nil {
+		return fmt.Errorf("Failed to check validity of identity auth group associations: %w", err)
+	}
+
+	var missingGroupNames []string
+	for _, groupName := range uniqueGroupNames {
+		if !shared.ValueInSlice(groupName, existingGroupNames) {
+			missingGroupNames = append(missingGroupNames, groupName)
+		}
+	}
+
+	return api.StatusErrorf(http.StatusNotFound, "Auth groups not found: %s", strings.Join(missingGroupNames, ", "))
 }
@@ -0,0 +1,54 @@
+//go:build linux && cgo && !agent
+
+package cluster
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/canonical/lxd/shared/api"
+)
+
+func TestSetIdentityAuthGroups(t *testing.T) {
+	schema := Schema()
+	db, err := schema.ExerciseUpdate(len(updates), nil)
+	require.NoError(t, err)
+
+	_, err = db.Exec(`INSERT INTO auth_groups (name, description) VALUES ('foo', ''), ('bar', '')`)
+	require.NoError(t, err)
+
+	_, err = db.Exec(`INSERT INTO identities (id, auth_method, type, identifier, name, metadata) VALUES (1, ?, ?, 'jane.doe@example.com', 'Jane Doe', '{}')`, authMethodOIDC, identityTypeOIDCClient)
+	require.NoError(t, err)
+
+	tx, err := db.Begin()
+	require.NoError(t, err)
+	defer func() { _ = tx.Rollback() }()
+
+	ctx := context.Background()
+	groupNames := func() []string {
+		groups, err := GetAuthGroupsByIdentityID(ctx, tx, 1)
+		require.NoError(t, err)
+
+		names := make([]string, 0, len(groups))
+		for _, group := range groups {
+			names = append(names, group.Name)
+		}
+
+		return names
+	}
+
+	// Duplicated group names are only added once.
+	err = SetIdentityAuthGroups(ctx, tx, 1, []string{"foo", "bar", "foo"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"foo", "bar"}, groupNames())
+
+	// Missing groups are reported by name with a not found error.
+	err = SetIdentityAuthGroups(ctx, tx, 1, []string{"foo", "missing", "other"})
+	require.Error(t, err)
+	assert.True(t, api.StatusErrorCheck(err, http.StatusNotFound))
+	assert.ErrorContains(t, err, "Auth groups not found: missing, other")
+}
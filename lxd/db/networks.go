@@ -14,6 +14,7 @@ import (
 	"github.com/canonical/lxd/lxd/db/query"
 	"github.com/canonical/lxd/shared"
 	"github.com/canonical/lxd/shared/api"
+	"github.com/canonical/lxd/shared/logger"
 	"github.com/canonical/lxd/shared/version"
 )
 
@@ -430,18 +431,26 @@ func (c *ClusterTx) NetworkNodes(ctx context.Context, networkID int64) (map[int6
 	nodes := []NetworkNode{}
 
 	sql := `
-		SELECT nodes.id, nodes.name, networks_nodes.state FROM nodes
+		SELECT nodes.id, nodes.name, networks_nodes.state, networks.state FROM nodes
 		JOIN networks_nodes ON networks_nodes.node_id = nodes.id
+		JOIN networks ON networks_nodes.network_id = networks.id
 		WHERE networks_nodes.network_id = ?
 	`
 	err := query.Scan(ctx, c.tx, sql, func(scan func(dest ...any) error) error {
 		node := NetworkNode{}
+		var networkState NetworkState
 
-		err := scan(&node.ID, &node.Name, &node.State)
+		err := scan(&node.ID, &node.Name, &node.State, &networkState)
 		if err != nil {
 			return err
 		}
 
+		if node.State == networkErrored {
+			// The network_errored_member_state patch rewrites these states, so this should not be reached.
+			node.State = normalizeNetworkNodeState(node.State, networkState)
+			logger.Debug("Normalized deprecated errored network member state", logger.Ctx{"networkID": networkID, "member": node.Name, "status": NetworkStateToAPIStatus(node.State)})
+		}
+
 		nodes = append(nodes, node)
 
 		return nil
@@ -458,6 +467,47 @@ func (c *ClusterTx) NetworkNodes(ctx context.Context, networkID int64) (map[int6
 	return netNodes, nil
 }
 
+// normalizeNetworkNodeState maps the deprecated networkErrored member state onto a state that is still in use.
+// Member states are no longer set to networkErrored, but it may still be present in databases written by older
+// versions. If the network has since been created globally then the member must have been created too, otherwise the
+// member is considered pending so that it is set up again on the next create attempt.
+func normalizeNetworkNodeState(nodeState NetworkState, networkState NetworkState) NetworkState {
+	if nodeState != networkErrored {
+		return nodeState
+	}
+
+	if networkState == networkCreated {
+		return networkCreated
+	}
+
+	return networkPending
+}
+
+// NormalizeErroredNetworkNodeStates rewrites any deprecated networkErrored member states in the database. Members of
+// networks that have been created globally are set to networkCreated, all others are set to networkPending (see
+// normalizeNetworkNodeState). It returns the number of members that were updated.
+func (c *ClusterTx) NormalizeErroredNetworkNodeStates(ctx context.Context) (int64, error) {
+	stmt := `
+UPDATE networks_nodes SET state = CASE
+	WHEN (SELECT networks.state FROM networks WHERE networks.id = networks_nodes.network_id) = ? THEN ?
+	ELSE ?
+END
+WHERE state = ?
+`
+
+	result, err := c.tx.ExecContext(ctx, stmt, networkCreated, networkCreated, networkPending, networkErrored)
+	if err != nil {
+		return -1, fmt.Errorf("Failed updating errored network member states: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return -1, fmt.Errorf("Failed getting number of updated network members: %w", err)
+	}
+
+	return n, nil
+}
+
 // GetNetworkURIs returns the URIs for the networks with the given project.
 func (c *ClusterTx) GetNetworkURIs(ctx context.Context, projectID int, project string) ([]string, error) {
 	sql := `SELECT networks.name from networks WHERE networks.project_id = ?`
@@ -106,3 +106,82 @@ func TestNetworksCreatePending_NonExistingNode(t *testing.T) {
 	err := tx.CreatePendingNetwork(context.Background(), "buzz", api.ProjectDefaultName, "network1", db.NetworkTypeBridge, map[string]string{})
 	require.True(t, response.IsNotFoundError(err))
 }
+
+// Members in the deprecated errored state are normalized when loaded.
+func TestNetworkNodes_LegacyErroredState(t *testing.T) {
+	tx, cleanup := db.NewTestClusterTx(t)
+	defer cleanup()
+
+	nodeID, err := tx.CreateNode("buzz", "1.2.3.4:666")
+	require.NoError(t, err)
+
+	err = tx.CreatePendingNetwork(context.Background(), "buzz", api.ProjectDefaultName, "network1", db.NetworkTypeBridge, map[string]string{})
+	require.NoError(t, err)
+
+	networkID, err := tx.GetNetworkID(context.Background(), api.ProjectDefaultName, "network1")
+	require.NoError(t, err)
+
+	// Simulate a member state written by an older version.
+	_, err = tx.Tx().Exec("UPDATE networks_nodes SET state = 2 WHERE network_id = ? AND node_id = ?", networkID, nodeID)
+	require.NoError(t, err)
+
+	// The network is still pending, so the member is considered pending.
+	nodes, err := tx.NetworkNodes(context.Background(), networkID)
+	require.NoError(t, err)
+	require.Contains(t, nodes, nodeID)
+	assert.Equal(t, api.NetworkStatusPending, db.NetworkStateToAPIStatus(nodes[nodeID].State))
+
+	// Once the network has been created globally, the member must have been created too.
+	err = tx.NetworkCreated(api.ProjectDefaultName, "network1")
+	require.NoError(t, err)
+
+	nodes, err = tx.NetworkNodes(context.Background(), networkID)
+	require.NoError(t, err)
+	require.Contains(t, nodes, nodeID)
+	assert.Equal(t, api.NetworkStatusCreated, db.NetworkStateToAPIStatus(nodes[nodeID].State))
+}
+
+// Deprecated errored member states are rewritten according to the state of their network.
+func TestNormalizeErroredNetworkNodeStates(t *testing.T) {
+	tx, cleanup := db.NewTestClusterTx(t)
+	defer cleanup()
+
+	nodeID, err := tx.CreateNode("buzz", "1.2.3.4:666")
+	require.NoError(t, err)
+
+	for _, name := range []string{"pending", "created"} {
+		err = tx.CreatePendingNetwork(context.Background(), "buzz", api.ProjectDefaultName, name, db.NetworkTypeBridge, map[string]string{})
+		require.NoError(t, err)
+	}
+
+	err = tx.NetworkCreated(api.ProjectDefaultName, "created")
+	require.NoError(t, err)
+
+	// Simulate member states written by an older version.
+	_, err = tx.Tx().Exec("UPDATE networks_nodes SET state = 2 WHERE node_id = ?", nodeID)
+	require.NoError(t, err)
+
+	updated, err := tx.NormalizeErroredNetworkNodeStates(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), updated)
+
+	expected := map[string]string{
+		"pending": api.NetworkStatusPending,
+		"created": api.NetworkStatusCreated,
+	}
+
+	for name, status := range expected {
+		networkID, err := tx.GetNetworkID(context.Background(), api.ProjectDefaultName, name)
+		require.NoError(t, err)
+
+		var state db.NetworkState
+		err = tx.Tx().QueryRow("SELECT state FROM networks_nodes WHERE network_id = ? AND node_id = ?", networkID, nodeID).Scan(&state)
+		require.NoError(t, err)
+		assert.Equal(t, status, db.NetworkStateToAPIStatus(state))
+	}
+
+	// Running it again is a no-op.
+	updated, err = tx.NormalizeErroredNetworkNodeStates(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), updated)
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	openfgav1 "github.com/openfga/api/proto/openfga/v1"
@@ -33,7 +34,10 @@ func NewOpenFGAStore(clusterDB *db.Cluster) storage.OpenFGADatastore {
 // openfgaStore is an implementation of storage.OpenFGADatastore that reads directly from our cluster database.
 type openfgaStore struct {
 	clusterDB *db.Cluster
-	model     *openfgav1.AuthorizationModel
+
+	// model is set by the OpenFGA server on start up and read on every request, so access is guarded by modelMu.
+	model   *openfgav1.AuthorizationModel
+	modelMu sync.RWMutex
 }
 
 // Read reads multiple tuples from the store. Various predicates are applied based on the given key.
@@ -276,7 +280,7 @@ WHERE auth_groups_permissions.entitlement = ? AND auth_groups_permissions.entity
 		return nil
 	})
 	if err != nil {
-		if !api.StatusErrorCheck(err, http.StatusNotFound) {
+		if api.StatusErrorCheck(err, http.StatusNotFound) {
 			// If we have a not found error then there are no tuples to return, but the datastore shouldn't return an error.
 			return storage.NewStaticTupleIterator(nil), nil
 		}
@@ -527,14 +531,26 @@ func (*openfgaStore) Write(ctx context.Context, store string, d storage.Deletes,
 
 // WriteAuthorizationModel sets the model.
 func (o *openfgaStore) WriteAuthorizationModel(ctx context.Context, store string, model *openfgav1.AuthorizationModel) error {
+	o.modelMu.Lock()
+	defer o.modelMu.Unlock()
+
 	o.model = model
 	return nil
 }
 
+// getModel returns the model that has been set, or nil if it hasn't been set.
+func (o *openfgaStore) getModel() *openfgav1.AuthorizationModel {
+	o.modelMu.RLock()
+	defer o.modelMu.RUnlock()
+
+	return o.model
+}
+
 // ReadAuthorizationModel returns the model that has been set or an error if it hasn't been set.
 func (o *openfgaStore) ReadAuthorizationModel(ctx context.Context, store string, id string) (*openfgav1.AuthorizationModel, error) {
-	if o.model != nil {
-		return o.model, nil
+	model := o.getModel()
+	if model != nil {
+		return model, nil
 	}
 
 	return nil, storage.ErrNotFound
@@ -542,8 +558,9 @@ func (o *openfgaStore) ReadAuthorizationModel(ctx context.Context, store string,
 
 // FindLatestAuthorizationModel returns the model that has been set or an error if it hasn't been set.
 func (o *openfgaStore) FindLatestAuthorizationModel(ctx context.Context, store string) (*openfgav1.AuthorizationModel, error) {
-	if o.model != nil {
-		return o.model, nil
+	model := o.getModel()
+	if model != nil {
+		return model, nil
 	}
 
 	return nil, storage.ErrNotFound
@@ -551,8 +568,9 @@ func (o *openfgaStore) FindLatestAuthorizationModel(ctx context.Context, store s
 
 // ReadAuthorizationModels returns a slice containing our own model or an error if it hasn't been set yet.
 func (o *openfgaStore) ReadAuthorizationModels(ctx context.Context, store string, options storage.PaginationOptions) ([]*openfgav1.AuthorizationModel, []byte, error) {
-	if o.model != nil {
-		return []*openfgav1.AuthorizationModel{o.model}, nil, nil
+	model := o.getModel()
+	if model != nil {
+		return []*openfgav1.AuthorizationModel{model}, nil, nil
 	}
 
 	return nil, nil, fmt.Errorf("Authorization model not set")
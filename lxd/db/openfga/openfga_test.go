@@ -0,0 +1,67 @@
+//go:build linux && cgo && !agent
+
+package openfga_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	openfgav1 "github.com/openfga/api/proto/openfga/v1"
+	"github.com/openfga/openfga/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/canonical/lxd/lxd/auth"
+	"github.com/canonical/lxd/lxd/db"
+	"github.com/canonical/lxd/lxd/db/openfga"
+	"github.com/canonical/lxd/shared/entity"
+)
+
+// ReadUsersetTuples returns no tuples, rather than an error, when the object does not exist.
+func TestReadUsersetTuples_NotFound(t *testing.T) {
+	cluster, cleanup := db.NewTestCluster(t)
+	defer cleanup()
+
+	store := openfga.NewOpenFGAStore(cluster)
+	it, err := store.ReadUsersetTuples(context.Background(), "", storage.ReadUsersetTuplesFilter{
+		Object:   fmt.Sprintf("%s:%s", entity.TypeProject, entity.ProjectURL("missing")),
+		Relation: string(auth.EntitlementCanView),
+	})
+	require.NoError(t, err)
+
+	_, err = it.Next(context.Background())
+	assert.ErrorIs(t, err, storage.ErrIteratorDone)
+}
+
+// The authorization model can be written and read concurrently. Run with -race to detect unsynchronised access.
+func TestAuthorizationModelConcurrentAccess(t *testing.T) {
+	store := openfga.NewOpenFGAStore(nil)
+
+	_, err := store.FindLatestAuthorizationModel(context.Background(), "")
+	require.ErrorIs(t, err, storage.ErrNotFound)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+
+		go func(i int) {
+			defer wg.Done()
+			err := store.WriteAuthorizationModel(context.Background(), "", &openfgav1.AuthorizationModel{Id: fmt.Sprintf("model-%d", i)})
+			assert.NoError(t, err)
+		}(i)
+
+		go func() {
+			defer wg.Done()
+			_, _ = store.ReadAuthorizationModel(context.Background(), "", "")
+			_, _, _ = store.ReadAuthorizationModels(context.Background(), "", storage.PaginationOptions{})
+		}()
+	}
+
+	wg.Wait()
+
+	model, err := store.FindLatestAuthorizationModel(context.Background(), "")
+	require.NoError(t, err)
+	assert.NotEmpty(t, model.GetId())
+}
@@ -715,13 +715,25 @@ func patchIdentity(d *Daemon, r *http.Request) response.Response {
 			return err
 		}
 
+		// The groups in apiIdentity are filtered by what the caller can view, so get the full list of groups
+		// to avoid removing the identity from groups that the caller can't see.
+		dbGroups, err := dbCluster.GetAuthGroupsByIdentityID(ctx, tx.Tx(), id.ID)
+		if err != nil {
+			return err
+		}
+
+		groupNames := make([]string, 0, len(dbGroups)+len(identityPut.Groups))
+		for _, group := range dbGroups {
+			groupNames = append(groupNames, group.Name)
+		}
+
 		for _, groupName := range identityPut.Groups {
-			if !shared.ValueInSlice(groupName, apiIdentity.Groups) {
-				apiIdentity.Groups = append(apiIdentity.Groups, groupName)
+			if !shared.ValueInSlice(groupName, groupNames) {
+				groupNames = append(groupNames, groupName)
 			}
 		}
 
-		err = dbCluster.SetIdentityAuthGroups(ctx, tx.Tx(), id.ID, identityPut.Groups)
+		err = dbCluster.SetIdentityAuthGroups(ctx, tx.Tx(), id.ID, groupNames)
 		if err != nil {
 			return err
 		}
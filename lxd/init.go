@@ -9,13 +9,22 @@ import (
 	"github.com/canonical/lxd/shared/revert"
 )
 
+// initDataNodeApplyArgs contains optional arguments for initDataNodeApply.
+type initDataNodeApplyArgs struct {
+	// KeepCreatedOnFailure prevents entities that were successfully created from being deleted when a later step
+	// fails (or when the returned revert function is called). Changes to pre-existing entities are still reverted.
+	// This makes re-running a partially applied preseed non-destructive, at the cost of leaving the server in a
+	// partially configured state that must be fixed up by the caller (e.g. by re-running the preseed).
+	KeepCreatedOnFailure bool
+}
+
 // Helper to initialize node-specific entities on a LXD instance using the
 // definitions from the given api.InitLocalPreseed object.
 //
 // It's used both by the 'lxd init' command and by the PUT /1.0/cluster API.
 //
 // In case of error, the returned function can be used to revert the changes.
-func initDataNodeApply(d lxd.InstanceServer, config api.InitLocalPreseed) (func(), error) {
+func initDataNodeApply(d lxd.InstanceServer, config api.InitLocalPreseed, args initDataNodeApplyArgs) (func(), error) {
 	revert := revert.New()
 	defer revert.Fail()
 
@@ -65,7 +74,10 @@ func initDataNodeApply(d lxd.InstanceServer, config api.InitLocalPreseed) (func(
 			}
 
 			// Setup reverter.
-			revert.Add(func() { _ = d.DeleteStoragePool(storagePool.Name) })
+			if !args.KeepCreatedOnFailure {
+				revert.Add(func() { _ = d.DeleteStoragePool(storagePool.Name) })
+			}
+
 			return nil
 		}
 
@@ -141,7 +153,9 @@ func initDataNodeApply(d lxd.InstanceServer, config api.InitLocalPreseed) (func(
 			}
 
 			// Setup reverter.
-			revert.Add(func() { _ = d.UseProject(network.Project).DeleteNetwork(network.Name) })
+			if !args.KeepCreatedOnFailure {
+				revert.Add(func() { _ = d.UseProject(network.Project).DeleteNetwork(network.Name) })
+			}
 		} else {
 			// Prepare the update.
 			newNetwork := api.NetworkPut{}
@@ -211,7 +225,10 @@ func initDataNodeApply(d lxd.InstanceServer, config api.InitLocalPreseed) (func(
 			}
 
 			// Setup reverter.
-			revert.Add(func() { _ = d.DeleteProject(project.Name) })
+			if !args.KeepCreatedOnFailure {
+				revert.Add(func() { _ = d.DeleteProject(project.Name) })
+			}
+
 			return nil
 		}
 
@@ -296,9 +313,11 @@ func initDataNodeApply(d lxd.InstanceServer, config api.InitLocalPreseed) (func(
 			}
 
 			// Setup reverter.
-			revert.Add(func() {
-				_ = d.UseProject(storageVolume.Project).DeleteStoragePoolVolume(storageVolume.Pool, storageVolume.Type, storageVolume.Name)
-			})
+			if !args.KeepCreatedOnFailure {
+				revert.Add(func() {
+					_ = d.UseProject(storageVolume.Project).DeleteStoragePoolVolume(storageVolume.Pool, storageVolume.Type, storageVolume.Name)
+				})
+			}
 		} else {
 			// Quick check.
 			if currentStorageVolume.Type != storageVolume.Type {
@@ -371,7 +390,10 @@ func initDataNodeApply(d lxd.InstanceServer, config api.InitLocalPreseed) (func(
 			}
 
 			// Setup reverter.
-			revert.Add(func() { _ = d.DeleteProfile(profile.Name) })
+			if !args.KeepCreatedOnFailure {
+				revert.Add(func() { _ = d.DeleteProfile(profile.Name) })
+			}
+
 			return nil
 		}
 
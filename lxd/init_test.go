@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/canonical/lxd/client"
+	"github.com/canonical/lxd/shared/api"
+)
+
+// initTestServer is a minimal lxd.InstanceServer that records networks and profiles created by initDataNodeApply.
+// Any method that is not overridden panics when called.
+type initTestServer struct {
+	lxd.InstanceServer
+
+	networks    []string
+	profiles    []string
+	failProfile string
+}
+
+func (s *initTestServer) UseProject(name string) lxd.InstanceServer {
+	return s
+}
+
+func (s *initTestServer) GetNetwork(name string) (*api.Network, string, error) {
+	return nil, "", api.StatusErrorf(http.StatusNotFound, "Network not found")
+}
+
+func (s *initTestServer) CreateNetwork(network api.NetworksPost) error {
+	s.networks = append(s.networks, network.Name)
+	return nil
+}
+
+func (s *initTestServer) DeleteNetwork(name string) error {
+	for i, network := range s.networks {
+		if network == name {
+			s.networks = append(s.networks[:i], s.networks[i+1:]...)
+			return nil
+		}
+	}
+
+	return api.StatusErrorf(http.StatusNotFound, "Network not found")
+}
+
+func (s *initTestServer) GetProfileNames() ([]string, error) {
+	return []string{"default"}, nil
+}
+
+func (s *initTestServer) CreateProfile(profile api.ProfilesPost) error {
+	if profile.Name == s.failProfile {
+		return fmt.Errorf("Profile creation failed")
+	}
+
+	s.profiles = append(s.profiles, profile.Name)
+	return nil
+}
+
+func (s *initTestServer) DeleteProfile(name string) error {
+	for i, profile := range s.profiles {
+		if profile == name {
+			s.profiles = append(s.profiles[:i], s.profiles[i+1:]...)
+			return nil
+		}
+	}
+
+	return api.StatusErrorf(http.StatusNotFound, "Profile not found")
+}
+
+func TestInitDataNodeApply_KeepCreatedOnFailure(t *testing.T) {
+	config := api.InitLocalPreseed{
+		Networks: []api.InitNetworksProjectPost{
+			{
+				NetworksPost: api.NetworksPost{Name: "lxdbr0", Type: "bridge"},
+				Project:      api.ProjectDefaultName,
+			},
+		},
+		Profiles: []api.ProfilesPost{
+			{Name: "p1"},
+			{Name: "broken"},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		args             initDataNodeApplyArgs
+		expectedNetworks []string
+		expectedProfiles []string
+	}{
+		{
+			name:             "Created entities are deleted by default",
+			args:             initDataNodeApplyArgs{},
+			expectedNetworks: []string{},
+			expectedProfiles: []string{},
+		},
+		{
+			name:             "Created entities are kept with KeepCreatedOnFailure",
+			args:             initDataNodeApplyArgs{KeepCreatedOnFailure: true},
+			expectedNetworks: []string{"lxdbr0"},
+			expectedProfiles: []string{"p1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &initTestServer{networks: []string{}, profiles: []string{}, failProfile: "broken"}
+
+			_, err := initDataNodeApply(server, config, tt.args)
+			require.Error(t, err)
+			assert.Equal(t, tt.expectedNetworks, server.networks)
+			assert.Equal(t, tt.expectedProfiles, server.profiles)
+		})
+	}
+}
+
+func TestInitDataNodeApply_KeepCreatedOnFailureRevert(t *testing.T) {
+	config := api.InitLocalPreseed{
+		Networks: []api.InitNetworksProjectPost{
+			{
+				NetworksPost: api.NetworksPost{Name: "lxdbr0", Type: "bridge"},
+				Project:      api.ProjectDefaultName,
+			},
+		},
+	}
+
+	// The returned revert function doesn't delete created entities either.
+	server := &initTestServer{networks: []string{}}
+	cleanup, err := initDataNodeApply(server, config, initDataNodeApplyArgs{KeepCreatedOnFailure: true})
+	require.NoError(t, err)
+
+	cleanup()
+	assert.Equal(t, []string{"lxdbr0"}, server.networks)
+}
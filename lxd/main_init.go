@@ -23,6 +23,8 @@ type cmdInit struct {
 	flagPreseed bool
 	flagDump    bool
 
+	flagKeepCreatedOnFailure bool
+
 	flagNetworkAddress  string
 	flagNetworkPort     int64
 	flagStorageBackend  string
@@ -45,7 +47,7 @@ func (c *cmdInit) Command() *cobra.Command {
   init --auto [--network-address=IP] [--network-port=8443] [--storage-backend=dir]
               [--storage-create-device=DEVICE] [--storage-create-loop=SIZE]
               [--storage-pool=POOL]
-  init --preseed
+  init --preseed [--keep-created-on-failure]
   init --dump
 `
 	cmd.RunE = c.Run
@@ -53,6 +55,7 @@ func (c *cmdInit) Command() *cobra.Command {
 	cmd.Flags().BoolVar(&c.flagMinimal, "minimal", false, "Minimal configuration (non-interactive)")
 	cmd.Flags().BoolVar(&c.flagPreseed, "preseed", false, "Pre-seed mode, expects YAML config from stdin")
 	cmd.Flags().BoolVar(&c.flagDump, "dump", false, "Dump YAML config to stdout")
+	cmd.Flags().BoolVar(&c.flagKeepCreatedOnFailure, "keep-created-on-failure", false, "Don't delete newly created entities if a later step fails (requires --preseed)")
 
 	cmd.Flags().StringVar(&c.flagNetworkAddress, "network-address", "", "Address to bind LXD to (default: none)"+"``")
 	cmd.Flags().Int64Var(&c.flagNetworkPort, "network-port", -1, fmt.Sprintf("Port to bind LXD to (default: %d)"+"``", shared.HTTPSDefaultPort))
@@ -85,6 +88,10 @@ func (c *cmdInit) Run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("Configuration flags require --auto")
 	}
 
+	if c.flagKeepCreatedOnFailure && !c.flagPreseed {
+		return fmt.Errorf("Can't use --keep-created-on-failure without --preseed")
+	}
+
 	if c.flagDump && (c.flagAuto || c.flagMinimal ||
 		c.flagPreseed || c.flagNetworkAddress != "" ||
 		c.flagNetworkPort != -1 || c.flagStorageBackend != "" ||
@@ -225,7 +232,7 @@ func (c *cmdInit) Run(cmd *cobra.Command, args []string) error {
 	revert := revert.New()
 	defer revert.Fail()
 
-	localRevert, err := initDataNodeApply(d, config.Node)
+	localRevert, err := initDataNodeApply(d, config.Node, initDataNodeApplyArgs{KeepCreatedOnFailure: c.flagKeepCreatedOnFailure})
 	if err != nil {
 		return err
 	}
@@ -91,6 +91,7 @@ var patches = []patch{
 	{name: "storage_move_custom_iso_block_volumes_v2", stage: patchPostDaemonStorage, run: patchStorageRenameCustomISOBlockVolumesV2},
 	{name: "storage_unset_invalid_block_settings_v2", stage: patchPostDaemonStorage, run: patchStorageUnsetInvalidBlockSettingsV2},
 	{name: "config_remove_core_trust_password", stage: patchPreLoadClusterConfig, run: patchRemoveCoreTrustPassword},
+	{name: "network_errored_member_state", stage: patchPostDaemonStorage, run: patchNetworkErroredMemberState},
 }
 
 type patch struct {
@@ -1447,4 +1448,25 @@ func patchRemoveCoreTrustPassword(_ string, d *Daemon) error {
 	return nil
 }
 
+// patchNetworkErroredMemberState replaces the deprecated errored network member state with pending or created,
+// depending on whether the network has been created globally.
+func patchNetworkErroredMemberState(name string, d *Daemon) error {
+	s := d.State()
+	var updated int64
+	err := s.DB.Cluster.Transaction(s.ShutdownCtx, func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+		updated, err = tx.NormalizeErroredNetworkNodeStates(ctx)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if updated > 0 {
+		logger.Warn("Normalized deprecated errored network member states", logger.Ctx{"patch": name, "members": updated})
+	}
+
+	return nil
+}
+
 // Patches end here
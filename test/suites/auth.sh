@@ -40,6 +40,19 @@ test_authorization() {
   ! lxc auth group permission remove test-group project default operator || false # Already removed
   ! lxc auth group permission add test-group project default not_a_project_entitlement || false # Invalid entitlement
 
+  # Patching a group adds the given permissions to the existing ones in a single request.
+  lxc auth group permission add test-group server viewer
+  lxc query -X PATCH /1.0/auth/groups/test-group -d '{"permissions": [{"entity_type": "project", "url": "/1.0/projects/default", "entitlement": "operator"}, {"entity_type": "project", "url": "/1.0/projects/default", "entitlement": "viewer"}]}'
+  [ "$(lxc query /1.0/auth/groups/test-group | jq '.permissions | length')" = "3" ]
+  ! lxc query -X PATCH /1.0/auth/groups/test-group -d '{"permissions": [{"entity_type": "project", "url": "/1.0/projects/default", "entitlement": "can_view"}, {"entity_type": "project", "url": "/1.0/projects/default", "entitlement": "not_a_project_entitlement"}]}' || false # Invalid entitlement fails the whole request
+  [ "$(lxc query /1.0/auth/groups/test-group | jq '.permissions | length')" = "3" ]
+  output="$(! lxc query -X PATCH /1.0/auth/groups/test-group -d '{"permissions": [{"entity_type": "project", "url": "/1.0/projects/not-found-1", "entitlement": "viewer"}, {"entity_type": "project", "url": "/1.0/projects/not-found-2", "entitlement": "viewer"}]}' 2>&1)" # Missing entities fail the whole request
+  echo "${output}" | grep -F "Entities not found" | grep -F "/1.0/projects/not-found-1" | grep -F "/1.0/projects/not-found-2"
+  [ "$(lxc query /1.0/auth/groups/test-group | jq '.permissions | length')" = "3" ]
+  lxc auth group permission remove test-group server viewer
+  lxc auth group permission remove test-group project default operator
+  lxc auth group permission remove test-group project default viewer
+
   # Instance permissions.
   ! lxc auth group permission add test-group instance c1 can_exec project=default || false # Not found
   lxc init testimage c1
@@ -96,6 +109,26 @@ EOF
 )
   lxc auth identity info oidc: | grep -Fz "${expected}"
 
+  # Patching an identity adds the given groups to its existing groups.
+  lxc auth group create test-group-2
+  lxc query -X PATCH /1.0/auth/identities/oidc/test-user@example.com -d '{"groups": ["test-group-2"]}'
+  [ "$(lxc query /1.0/auth/identities/oidc/test-user@example.com | jq -r '.groups | sort | join(",")')" = "test-group,test-group-2" ]
+  lxc auth identity group remove oidc/test-user@example.com test-group-2
+
+  # Patching an identity as a caller that can't view all of its groups keeps the groups hidden from the caller.
+  set_oidc other-user other-user@example.com
+  BROWSER=curl lxc remote add --accept-certificate oidc-other "${LXD_ADDR}" --auth-type oidc
+  set_oidc test-user test-user@example.com
+  lxc auth identity group add oidc/other-user@example.com test-group-2
+  lxc auth group permission add test-group-2 identity oidc/test-user@example.com can_view
+  lxc auth group permission add test-group-2 identity oidc/test-user@example.com can_edit
+  [ "$(lxc_remote query oidc-other:/1.0/auth/identities/oidc/test-user@example.com | jq '.groups | length')" = 0 ]
+  lxc_remote query -X PATCH oidc-other:/1.0/auth/identities/oidc/test-user@example.com -d '{"groups": ["test-group-2"]}'
+  [ "$(lxc query /1.0/auth/identities/oidc/test-user@example.com | jq -r '.groups | sort | join(",")')" = "test-group,test-group-2" ]
+  lxc auth identity group remove oidc/test-user@example.com test-group-2
+  lxc remote remove oidc-other
+  lxc auth group delete test-group-2
+
   ### IDENTITY PROVIDER GROUP MANAGEMENT ###
   lxc auth identity-provider-group create test-idp-group
   ! lxc auth identity-provider-group group add test-idp-group not-found || false # Group not found